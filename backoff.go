@@ -0,0 +1,91 @@
+package goperconn
+
+import (
+	"math/rand"
+	"time"
+)
+
+// DefaultMultiplier is the default factor by which the retry interval grows after each failed
+// connection attempt.
+const DefaultMultiplier = 1.5
+
+// DefaultRandomizationFactor is the default fraction by which the retry interval is randomized, to
+// prevent many clients reconnecting to the same downed remote host from synchronizing their retries.
+const DefaultRandomizationFactor = 0.5
+
+// Stop is returned by NextBackOff to indicate the caller should stop retrying.
+const Stop time.Duration = -1
+
+// BackOff computes the amount of time to wait between connection attempts.
+type BackOff interface {
+	// NextBackOff returns the duration to wait before the next connection attempt, or Stop if no
+	// more attempts should be made.
+	NextBackOff() time.Duration
+
+	// Reset discards any accumulated state, causing the next call to NextBackOff to behave as if
+	// it were the first.
+	Reset()
+}
+
+// ExponentialBackOff is a BackOff that starts at InitialInterval and grows by Multiplier on each
+// call to NextBackOff, up to MaxInterval, with up to RandomizationFactor of jitter applied to each
+// returned interval. If MaxElapsedTime is non-zero, NextBackOff returns Stop once that much time has
+// elapsed since the backoff was created or last Reset.
+type ExponentialBackOff struct {
+	InitialInterval     time.Duration
+	MaxInterval         time.Duration
+	Multiplier          float64
+	RandomizationFactor float64
+	MaxElapsedTime      time.Duration
+
+	currentInterval time.Duration
+	startTime       time.Time
+}
+
+// NewExponentialBackOff returns an ExponentialBackOff with the given InitialInterval and
+// MaxInterval, DefaultMultiplier, DefaultRandomizationFactor, and no MaxElapsedTime.
+func NewExponentialBackOff(initialInterval, maxInterval time.Duration) *ExponentialBackOff {
+	b := &ExponentialBackOff{
+		InitialInterval:     initialInterval,
+		MaxInterval:         maxInterval,
+		Multiplier:          DefaultMultiplier,
+		RandomizationFactor: DefaultRandomizationFactor,
+	}
+	b.Reset()
+	return b
+}
+
+// Reset discards any accumulated state, causing the next call to NextBackOff to return a duration
+// near InitialInterval.
+func (b *ExponentialBackOff) Reset() {
+	b.currentInterval = b.InitialInterval
+	b.startTime = time.Now()
+}
+
+// NextBackOff returns the next interval to wait, or Stop when MaxElapsedTime has elapsed.
+func (b *ExponentialBackOff) NextBackOff() time.Duration {
+	if b.MaxElapsedTime != 0 && time.Since(b.startTime) > b.MaxElapsedTime {
+		return Stop
+	}
+
+	interval := jitter(b.currentInterval, b.RandomizationFactor)
+
+	next := time.Duration(float64(b.currentInterval) * b.Multiplier)
+	if next > b.MaxInterval {
+		next = b.MaxInterval
+	}
+	b.currentInterval = next
+
+	return interval
+}
+
+// jitter returns a duration uniformly sampled from [interval*(1-factor), interval*(1+factor)].
+func jitter(interval time.Duration, factor float64) time.Duration {
+	if factor <= 0 {
+		return interval
+	}
+	delta := factor * float64(interval)
+	min := float64(interval) - delta
+	max := float64(interval) + delta
+	return time.Duration(min + rand.Float64()*(max-min))
+}
@@ -0,0 +1,81 @@
+package goperconn
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitter(t *testing.T) {
+	interval := 100 * time.Millisecond
+	factor := 0.5
+	min := time.Duration(float64(interval) * (1 - factor))
+	max := time.Duration(float64(interval) * (1 + factor))
+
+	for i := 0; i < 1000; i++ {
+		got := jitter(interval, factor)
+		if got < min || got > max {
+			t.Fatalf("jitter(%s, %f) = %s; want within [%s, %s]", interval, factor, got, min, max)
+		}
+	}
+}
+
+func TestJitterZeroFactor(t *testing.T) {
+	interval := 250 * time.Millisecond
+	if got := jitter(interval, 0); got != interval {
+		t.Errorf("jitter with zero factor = %s; want %s", got, interval)
+	}
+}
+
+func TestExponentialBackOffGrowth(t *testing.T) {
+	b := &ExponentialBackOff{
+		InitialInterval:     10 * time.Millisecond,
+		MaxInterval:         40 * time.Millisecond,
+		Multiplier:          2,
+		RandomizationFactor: 0, // disable jitter so growth is deterministic
+	}
+	b.Reset()
+
+	want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond}
+	for i, expected := range want {
+		got := b.NextBackOff()
+		if got != expected {
+			t.Errorf("NextBackOff() call %d = %s; want %s", i, got, expected)
+		}
+	}
+}
+
+func TestExponentialBackOffMaxElapsedTime(t *testing.T) {
+	b := &ExponentialBackOff{
+		InitialInterval:     time.Millisecond,
+		MaxInterval:         time.Millisecond,
+		Multiplier:          1,
+		RandomizationFactor: 0,
+		MaxElapsedTime:      10 * time.Millisecond,
+	}
+	b.Reset()
+
+	time.Sleep(20 * time.Millisecond)
+
+	if got := b.NextBackOff(); got != Stop {
+		t.Errorf("NextBackOff() after MaxElapsedTime elapsed = %s; want Stop", got)
+	}
+}
+
+func TestExponentialBackOffReset(t *testing.T) {
+	b := &ExponentialBackOff{
+		InitialInterval:     10 * time.Millisecond,
+		MaxInterval:         time.Second,
+		Multiplier:          2,
+		RandomizationFactor: 0,
+	}
+	b.Reset()
+
+	_ = b.NextBackOff() // 10ms
+	_ = b.NextBackOff() // 20ms
+
+	b.Reset()
+
+	if got, want := b.NextBackOff(), 10*time.Millisecond; got != want {
+		t.Errorf("NextBackOff() after Reset = %s; want %s", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+package goperconn
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReadContextCancelsAgainstStuckDial(t *testing.T) {
+	conn, err := New(Address("ignored"), blockingDialer())
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	defer conn.Shutdown(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = conn.ReadContext(ctx, make([]byte, 1))
+	elapsed := time.Since(start)
+
+	ioErr, ok := err.(ErrIOError)
+	if !ok || !errors.Is(ioErr.Err, context.DeadlineExceeded) {
+		t.Errorf("ReadContext = %v; want ErrIOError wrapping context.DeadlineExceeded", err)
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("ReadContext took %s to return; want it to respect ctx without waiting on the stuck dial", elapsed)
+	}
+}
+
+func TestWriteContextCancelsAgainstStuckDial(t *testing.T) {
+	conn, err := New(Address("ignored"), blockingDialer())
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	defer conn.Shutdown(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = conn.WriteContext(ctx, []byte("hello"))
+	elapsed := time.Since(start)
+
+	ioErr, ok := err.(ErrIOError)
+	if !ok || !errors.Is(ioErr.Err, context.DeadlineExceeded) {
+		t.Errorf("WriteContext = %v; want ErrIOError wrapping context.DeadlineExceeded", err)
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("WriteContext took %s to return; want it to respect ctx without waiting on the stuck dial", elapsed)
+	}
+}
+
+func TestShutdownDuringStuckDialUnsticksPendingIO(t *testing.T) {
+	conn, err := New(Address("ignored"), blockingDialer())
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := conn.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Shutdown took %s; want it to unstick the stalled dial quickly", elapsed)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := conn.Read(make([]byte, 1))
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		ioErr, ok := err.(ErrIOError)
+		if !ok {
+			t.Fatalf("Read after Shutdown error type = %T; want ErrIOError", err)
+		}
+		if _, ok := ioErr.Err.(ErrShutdown); !ok {
+			t.Errorf("Read after Shutdown = %v; want ErrShutdown", ioErr.Err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Read after Shutdown hung instead of returning ErrShutdown")
+	}
+}
+
+func TestReadAfterCloseReturnsErrClosedConnection(t *testing.T) {
+	conn, err := New(Address("ignored"), pipeDialer(echoServer))
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	if err := conn.Close(); err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := conn.Read(make([]byte, 1))
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		ioErr, ok := err.(ErrIOError)
+		if !ok {
+			t.Fatalf("Read after Close error type = %T; want ErrIOError", err)
+		}
+		if _, ok := ioErr.Err.(ErrClosedConnection); !ok {
+			t.Errorf("Read after Close = %v; want ErrClosedConnection", ioErr.Err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Read after Close hung instead of returning ErrClosedConnection")
+	}
+}
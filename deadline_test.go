@@ -0,0 +1,139 @@
+package goperconn
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blackholeServer holds conn open without reading or writing anything until done is closed, so a
+// Read or Write against it only ever completes by timing out.
+func blackholeServer(done <-chan struct{}) func(net.Conn) {
+	return func(conn net.Conn) {
+		<-done
+		conn.Close()
+	}
+}
+
+func TestReadTimeoutExpires(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+
+	conn, err := New(Address("ignored"), pipeDialer(blackholeServer(done)), ReadTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	defer conn.Close()
+
+	start := time.Now()
+	_, err = conn.Read(make([]byte, 16))
+	elapsed := time.Since(start)
+
+	ioErr, ok := err.(ErrIOError)
+	if !ok {
+		t.Fatalf("Read error type = %T; want ErrIOError", err)
+	}
+	var netErr net.Error
+	if !errors.As(ioErr.Err, &netErr) || !netErr.Timeout() {
+		t.Errorf("Read error = %v; want a timeout error", ioErr.Err)
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("Read took %s to time out; want well under 500ms", elapsed)
+	}
+}
+
+func TestWriteTimeoutExpires(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+
+	conn, err := New(Address("ignored"), pipeDialer(blackholeServer(done)), WriteTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	defer conn.Close()
+
+	start := time.Now()
+	_, err = conn.Write([]byte("hello"))
+	elapsed := time.Since(start)
+
+	ioErr, ok := err.(ErrIOError)
+	if !ok {
+		t.Fatalf("Write error type = %T; want ErrIOError", err)
+	}
+	var netErr net.Error
+	if !errors.As(ioErr.Err, &netErr) || !netErr.Timeout() {
+		t.Errorf("Write error = %v; want a timeout error", ioErr.Err)
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("Write took %s to time out; want well under 500ms", elapsed)
+	}
+}
+
+// TestSetReadDeadlineSurvivesReconnect sets a read deadline, forces a reconnect by having the first
+// dial's server close immediately, then checks that the deadline set before the reconnect is still
+// honored against the new underlying net.Conn, rather than being lost along with the old one.
+func TestSetReadDeadlineSurvivesReconnect(t *testing.T) {
+	blackhole := make(chan struct{})
+	defer close(blackhole)
+	secondDialed := make(chan struct{})
+
+	var dials int32
+	conn, err := New(
+		Address("ignored"),
+		RetryMin(time.Millisecond),
+		RetryMax(time.Millisecond),
+		Dialer(func(ctx context.Context) (net.Conn, error) {
+			client, server := net.Pipe()
+			switch atomic.AddInt32(&dials, 1) {
+			case 1:
+				go server.Close() // breaks immediately, forcing a reconnect
+			case 2:
+				close(secondDialed)
+				go blackholeServer(blackhole)(server)
+			default:
+				// The deadline-triggered Read below also breaks this connection, causing a
+				// further reconnect attempt after the test has already made its assertions.
+				go blackholeServer(blackhole)(server)
+			}
+			return client, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetReadDeadline(time.Now().Add(20 * time.Millisecond)); err != nil {
+		t.Fatalf("SetReadDeadline: %s", err)
+	}
+
+	// Prime the broken first connection: this Read fails and triggers the reconnect.
+	if _, err := conn.Read(make([]byte, 16)); err == nil {
+		t.Fatal("Read on broken first connection: want error, got nil")
+	}
+
+	select {
+	case <-secondDialed:
+	case <-time.After(time.Second):
+		t.Fatal("reconnect never attempted a second dial")
+	}
+
+	start := time.Now()
+	_, err = conn.Read(make([]byte, 16))
+	elapsed := time.Since(start)
+
+	ioErr, ok := err.(ErrIOError)
+	if !ok {
+		t.Fatalf("Read error type = %T; want ErrIOError", err)
+	}
+	var netErr net.Error
+	if !errors.As(ioErr.Err, &netErr) || !netErr.Timeout() {
+		t.Errorf("Read error = %v; want a timeout error", ioErr.Err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("Read took %s to time out; want the deadline set before reconnect to still apply", elapsed)
+	}
+}
@@ -0,0 +1,102 @@
+package goperconn
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+// pipeDialer returns a Dialer Configurator whose connections are in-memory net.Pipe pairs serviced
+// by serve, so tests can exercise Conn's reconnect and I/O logic without a real network listener.
+func pipeDialer(serve func(net.Conn)) Configurator {
+	return Dialer(func(ctx context.Context) (net.Conn, error) {
+		client, server := net.Pipe()
+		go serve(server)
+		return client, nil
+	})
+}
+
+// echoServer copies everything read from conn back to conn until conn is closed or errors.
+func echoServer(conn net.Conn) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			if _, werr := conn.Write(buf[:n]); werr != nil {
+				break
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	conn.Close()
+}
+
+// blockingDialer returns a Dialer Configurator that never establishes a connection, simulating a
+// dial to a host that never responds. It honors ctx cancellation the same way net.Dialer does, so
+// Shutdown can still unstick a Conn stuck dialing.
+func blockingDialer() Configurator {
+	return Dialer(func(ctx context.Context) (net.Conn, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+}
+
+func TestDialerCustomRoundTrip(t *testing.T) {
+	conn, err := New(Address("ignored"), pipeDialer(echoServer))
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("hello, world")); err != nil {
+		t.Fatalf("Write: %s", err)
+	}
+
+	buf := make([]byte, 12)
+	if _, err := conn.Read(buf); err != nil {
+		t.Fatalf("Read: %s", err)
+	}
+	if got, want := string(buf), "hello, world"; got != want {
+		t.Errorf("Read = %q; want %q", got, want)
+	}
+}
+
+func TestDialerReceivesDialTimeoutContext(t *testing.T) {
+	type observation struct {
+		ok       bool
+		deadline time.Time
+	}
+	observations := make(chan observation, 1)
+
+	configuredTimeout := 50 * time.Millisecond
+	conn, err := New(
+		Address("ignored"),
+		DialTimeout(configuredTimeout),
+		Dialer(func(ctx context.Context) (net.Conn, error) {
+			deadline, ok := ctx.Deadline()
+			observations <- observation{ok: ok, deadline: deadline}
+			client, server := net.Pipe()
+			go echoServer(server)
+			return client, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	defer conn.Close()
+
+	select {
+	case obs := <-observations:
+		if !obs.ok {
+			t.Fatal("dial context has no deadline; want one derived from DialTimeout")
+		}
+		if until := time.Until(obs.deadline); until <= 0 || until > configuredTimeout {
+			t.Errorf("dial context deadline is %s from now; want within (0, %s]", until, configuredTimeout)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("custom Dialer was never invoked")
+	}
+}
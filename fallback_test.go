@@ -0,0 +1,127 @@
+package goperconn
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFallbackWriteWhileDisconnected(t *testing.T) {
+	var buf bytes.Buffer
+	conn, err := New(Address("ignored"), blockingDialer(), Fallback(&buf))
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	defer conn.Shutdown(context.Background())
+
+	n, err := conn.Write([]byte("hello"))
+	if err != nil {
+		t.Fatalf("Write: %s", err)
+	}
+	if n != 5 {
+		t.Errorf("Write returned n = %d; want 5", n)
+	}
+	if got := buf.String(); got != "hello" {
+		t.Errorf("fallback buffer = %q; want %q", got, "hello")
+	}
+}
+
+func TestFallbackWriteMessageWhileDisconnected(t *testing.T) {
+	var buf bytes.Buffer
+	conn, err := New(Address("ignored"), blockingDialer(), WithFramer(&LengthPrefixFramer{}), Fallback(&buf))
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	defer conn.Shutdown(context.Background())
+
+	if _, err := conn.WriteMessage([]byte("hello")); err != nil {
+		t.Fatalf("WriteMessage: %s", err)
+	}
+
+	f := &LengthPrefixFramer{}
+	got, err := f.ReadFrame(&buf)
+	if err != nil {
+		t.Fatalf("ReadFrame on fallback buffer: %s", err)
+	}
+	if string(got) != "hello" {
+		t.Errorf("fallback frame = %q; want %q", got, "hello")
+	}
+}
+
+// recordingLogger is a StructuredLogger that records each hook invocation, guarded by mu since the
+// reconnect goroutine invokes it concurrently with the test goroutine's assertions.
+type recordingLogger struct {
+	mu         sync.Mutex
+	dials      int
+	dialErrors int
+}
+
+func (r *recordingLogger) OnDial(address string) {
+	r.mu.Lock()
+	r.dials++
+	r.mu.Unlock()
+}
+
+func (r *recordingLogger) OnDialError(address string, err error) {
+	r.mu.Lock()
+	r.dialErrors++
+	r.mu.Unlock()
+}
+
+func (r *recordingLogger) OnDisconnect(address string, err error) {}
+
+func (r *recordingLogger) OnReconnect(attempt int, backoff time.Duration) {}
+
+func (r *recordingLogger) counts() (dials, dialErrors int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.dials, r.dialErrors
+}
+
+func TestStructuredLoggerHooks(t *testing.T) {
+	var failuresLeft int32 = 2
+	logger := &recordingLogger{}
+
+	conn, err := New(
+		Address("ignored"),
+		RetryMin(time.Millisecond),
+		RetryMax(5*time.Millisecond),
+		WithStructuredLogger(logger),
+		Dialer(func(ctx context.Context) (net.Conn, error) {
+			if atomic.AddInt32(&failuresLeft, -1) >= 0 {
+				return nil, errors.New("simulated dial failure")
+			}
+			client, server := net.Pipe()
+			go echoServer(server)
+			return client, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	defer conn.Shutdown(context.Background())
+
+	deadline := time.After(2 * time.Second)
+	for {
+		if _, dialErrors := logger.counts(); dialErrors >= 2 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for OnDialError to be called after simulated dial failures")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		t.Fatalf("Write after recovering from dial failures: %s", err)
+	}
+	if dials, _ := logger.counts(); dials < 3 {
+		t.Errorf("OnDial invoked %d times; want at least 3 (2 failures + 1 success)", dials)
+	}
+}
@@ -0,0 +1,116 @@
+package goperconn
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxFrameSize bounds how large a single frame either Framer implementation will allocate
+// for, guarding against a corrupt stream (or a runaway sender) causing an unbounded allocation.
+const DefaultMaxFrameSize = 1 << 20 // 1 MiB
+
+// Framer reads and writes discrete messages on a stream, so that WriteMessage and ReadMessage can
+// preserve message boundaries across the raw byte stream Read and Write otherwise expose. This
+// matters in particular across reconnects: without framing, a message half-written when the
+// connection drops silently splices onto whatever the peer reads next.
+type Framer interface {
+	// ReadFrame reads and returns the next complete frame from r.
+	ReadFrame(r io.Reader) ([]byte, error)
+
+	// WriteFrame writes data to w as a single frame, returning the number of bytes of data
+	// written.
+	WriteFrame(w io.Writer, data []byte) (int, error)
+}
+
+// LengthPrefixFramer frames messages with a 4-byte big-endian length prefix, suitable for
+// arbitrary binary payloads.
+type LengthPrefixFramer struct {
+	// MaxFrameSize bounds the size of a frame ReadFrame will allocate for. Zero means
+	// DefaultMaxFrameSize.
+	MaxFrameSize uint32
+}
+
+func (f *LengthPrefixFramer) maxFrameSize() uint32 {
+	if f.MaxFrameSize == 0 {
+		return DefaultMaxFrameSize
+	}
+	return f.MaxFrameSize
+}
+
+// ReadFrame reads a 4-byte big-endian length prefix followed by that many bytes of payload.
+func (f *LengthPrefixFramer) ReadFrame(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > f.maxFrameSize() {
+		return nil, fmt.Errorf("frame size %d exceeds maximum of %d", size, f.maxFrameSize())
+	}
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// WriteFrame writes data preceded by its 4-byte big-endian length.
+func (f *LengthPrefixFramer) WriteFrame(w io.Writer, data []byte) (int, error) {
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(data)))
+	if _, err := w.Write(header[:]); err != nil {
+		return 0, err
+	}
+	return w.Write(data)
+}
+
+// DelimiterFramer frames messages by a single delimiter byte, suitable for line-oriented protocols
+// such as Graphite, StatsD, or syslog.
+type DelimiterFramer struct {
+	// Delim is the byte that terminates each frame, such as '\n'.
+	Delim byte
+
+	// MaxFrameSize bounds how many bytes ReadFrame buffers while searching for Delim, guarding
+	// against an unterminated stream growing the buffer without limit. Zero means
+	// DefaultMaxFrameSize.
+	MaxFrameSize uint32
+}
+
+func (f *DelimiterFramer) maxFrameSize() uint32 {
+	if f.MaxFrameSize == 0 {
+		return DefaultMaxFrameSize
+	}
+	return f.MaxFrameSize
+}
+
+// ReadFrame reads bytes from r until Delim is found, returning the frame without the trailing
+// delimiter.
+func (f *DelimiterFramer) ReadFrame(r io.Reader) ([]byte, error) {
+	var frame []byte
+	var b [1]byte
+	for {
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return nil, err
+		}
+		if b[0] == f.Delim {
+			return frame, nil
+		}
+		if uint32(len(frame)) >= f.maxFrameSize() {
+			return nil, fmt.Errorf("frame exceeds maximum of %d bytes without finding delimiter", f.maxFrameSize())
+		}
+		frame = append(frame, b[0])
+	}
+}
+
+// WriteFrame writes data followed by Delim.
+func (f *DelimiterFramer) WriteFrame(w io.Writer, data []byte) (int, error) {
+	n, err := w.Write(data)
+	if err != nil {
+		return n, err
+	}
+	if _, err := w.Write([]byte{f.Delim}); err != nil {
+		return n, err
+	}
+	return n, nil
+}
@@ -0,0 +1,97 @@
+package goperconn
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestLengthPrefixFramerRoundTrip(t *testing.T) {
+	f := &LengthPrefixFramer{}
+	var buf bytes.Buffer
+
+	messages := [][]byte{[]byte("hello, world"), []byte(""), []byte("a second message")}
+	for _, m := range messages {
+		if _, err := f.WriteFrame(&buf, m); err != nil {
+			t.Fatalf("WriteFrame(%q): %s", m, err)
+		}
+	}
+
+	for _, want := range messages {
+		got, err := f.ReadFrame(&buf)
+		if err != nil {
+			t.Fatalf("ReadFrame: %s", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("ReadFrame = %q; want %q", got, want)
+		}
+	}
+}
+
+func TestLengthPrefixFramerMaxFrameSize(t *testing.T) {
+	f := &LengthPrefixFramer{MaxFrameSize: 4}
+	var buf bytes.Buffer
+
+	// Hand craft a frame whose length prefix exceeds MaxFrameSize.
+	header := []byte{0, 0, 0, 10}
+	buf.Write(header)
+	buf.Write(make([]byte, 10))
+
+	if _, err := f.ReadFrame(&buf); err == nil {
+		t.Error("ReadFrame with oversized length prefix: want error, got nil")
+	}
+}
+
+func TestLengthPrefixFramerShortRead(t *testing.T) {
+	f := &LengthPrefixFramer{}
+	var buf bytes.Buffer
+	buf.Write([]byte{0, 0, 0, 5})
+	buf.Write([]byte("ab")) // fewer than the 5 bytes promised by the header
+
+	if _, err := f.ReadFrame(&buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadFrame with truncated body: got %v, want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestDelimiterFramerRoundTrip(t *testing.T) {
+	f := &DelimiterFramer{Delim: '\n'}
+	var buf bytes.Buffer
+
+	messages := [][]byte{[]byte("metric.foo 1 123456"), []byte("metric.bar 2 123457")}
+	for _, m := range messages {
+		if _, err := f.WriteFrame(&buf, m); err != nil {
+			t.Fatalf("WriteFrame(%q): %s", m, err)
+		}
+	}
+
+	for _, want := range messages {
+		got, err := f.ReadFrame(&buf)
+		if err != nil {
+			t.Fatalf("ReadFrame: %s", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("ReadFrame = %q; want %q", got, want)
+		}
+	}
+}
+
+func TestDelimiterFramerMaxFrameSize(t *testing.T) {
+	f := &DelimiterFramer{Delim: '\n', MaxFrameSize: 4}
+	var buf bytes.Buffer
+	buf.WriteString("this line never terminates")
+
+	if _, err := f.ReadFrame(&buf); err == nil {
+		t.Error("ReadFrame with unterminated frame past MaxFrameSize: want error, got nil")
+	}
+}
+
+func TestDelimiterFramerNoDelimiter(t *testing.T) {
+	f := &DelimiterFramer{Delim: '\n'}
+	var buf bytes.Buffer
+	buf.WriteString("no newline here")
+
+	if _, err := f.ReadFrame(&buf); !errors.Is(err, io.EOF) {
+		t.Errorf("ReadFrame with no delimiter before EOF: got %v, want io.EOF", err)
+	}
+}
@@ -1,9 +1,13 @@
 package goperconn
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"net"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -38,6 +42,18 @@ func DialTimeout(duration time.Duration) Configurator {
 	}
 }
 
+// Dialer specifies the function used to establish the connection to the remote host, replacing the
+// default of dialing TCP to the configured Address. This allows callers to substitute their own dial
+// logic, such as establishing a TLS connection, dialing a Unix domain socket, or proxying through
+// SOCKS5. The context passed to dial is derived from DialTimeout when a non-zero timeout has been
+// configured.
+func Dialer(dial func(ctx context.Context) (net.Conn, error)) Configurator {
+	return func(c *Conn) error {
+		c.dial = dial
+		return nil
+	}
+}
+
 // Printer interface exposes the Print method.
 type Printer interface {
 	Print(...interface{})
@@ -69,15 +85,107 @@ func RetryMax(duration time.Duration) Configurator {
 	}
 }
 
+// ReadTimeout configures a rolling per-operation deadline applied to every Read, so that a stalled
+// remote host times out a single Read rather than blocking forever, without requiring the caller to
+// call SetReadDeadline before every operation.
+func ReadTimeout(duration time.Duration) Configurator {
+	return func(c *Conn) error {
+		c.readTimeout = duration
+		return nil
+	}
+}
+
+// WriteTimeout configures a rolling per-operation deadline applied to every Write, so that a stalled
+// remote host times out a single Write rather than blocking forever, without requiring the caller to
+// call SetWriteDeadline before every operation.
+func WriteTimeout(duration time.Duration) Configurator {
+	return func(c *Conn) error {
+		c.writeTimeout = duration
+		return nil
+	}
+}
+
+// WithBackOff overrides the default exponential backoff strategy used to space out reconnection
+// attempts, so callers can substitute their own strategy in place of the default
+// ExponentialBackOff built from RetryMin and RetryMax.
+func WithBackOff(b BackOff) Configurator {
+	return func(c *Conn) error {
+		c.backOff = b
+		return nil
+	}
+}
+
+// WithFramer configures a Framer used to preserve message boundaries, enabling the WriteMessage
+// and ReadMessage methods.
+func WithFramer(f Framer) Configurator {
+	return func(c *Conn) error {
+		c.framer = f
+		return nil
+	}
+}
+
+// Fallback specifies a writer Write and WriteMessage use while the connection to the remote host
+// is down, rather than blocking until the connection is reestablished. This is essential for
+// log-forwarding sidecars, where dropping writes on the floor during an outage is unacceptable; a
+// typical Fallback is os.Stderr or a local spool file.
+func Fallback(w io.Writer) Configurator {
+	return func(c *Conn) error {
+		c.fallback = w
+		return nil
+	}
+}
+
+// StructuredLogger receives structured connection lifecycle events, so operators can wire
+// goperconn into slog, zap, or zerolog with typed fields instead of parsing ErrDialFailure and
+// ErrIOError strings out of a Printer.
+type StructuredLogger interface {
+	// OnDial is invoked immediately before each attempt to dial address.
+	OnDial(address string)
+
+	// OnDialError is invoked when an attempt to dial address fails.
+	OnDialError(address string, err error)
+
+	// OnDisconnect is invoked when an established connection to address is lost.
+	OnDisconnect(address string, err error)
+
+	// OnReconnect is invoked after a failed dial or a lost connection, reporting how many
+	// consecutive attempts have failed and how long the client will wait before the next one.
+	OnReconnect(attempt int, backoff time.Duration)
+}
+
+// WithStructuredLogger configures a StructuredLogger to invoke with connection lifecycle events.
+func WithStructuredLogger(logger StructuredLogger) Configurator {
+	return func(c *Conn) error {
+		c.structuredLogger = logger
+		return nil
+	}
+}
+
 // Conn wraps a net.Conn, providing a pseudo-persistent network connection.
 type Conn struct {
 	net.Conn
 	address     string
+	backOff     BackOff
+	dial        func(ctx context.Context) (net.Conn, error)
 	dialTimeout time.Duration
+	framer      Framer
 	jobs        chan *rillJob
 	printer     Printer
 	retryMax    time.Duration
 	retryMin    time.Duration
+
+	fallback         io.Writer
+	structuredLogger StructuredLogger
+	connected        int32 // accessed atomically; 1 while a connection is established
+
+	mu            sync.Mutex
+	readDeadline  time.Time
+	writeDeadline time.Time
+	readTimeout   time.Duration
+	writeTimeout  time.Duration
+
+	shutdownCtx    context.Context
+	shutdownCancel context.CancelFunc
 }
 
 // New returns a Conn structure that wraps the net.Conn connection, and attempts to provide a
@@ -142,43 +250,119 @@ func New(setters ...Configurator) (*Conn, error) {
 	if client.address == "" {
 		return nil, fmt.Errorf("cannot create Conn with address: %q", client.address)
 	}
+	if client.dial == nil {
+		client.dial = func(ctx context.Context) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "tcp", client.address)
+		}
+	}
+	if client.backOff == nil {
+		client.backOff = NewExponentialBackOff(client.retryMin, client.retryMax)
+	}
+	client.shutdownCtx, client.shutdownCancel = context.WithCancel(context.Background())
 	go func(wrapper *Conn) {
 		var conn net.Conn
 		var err error
-		retry := client.retryMin
+		var attempt int
 		for {
-			if client.dialTimeout == 0 {
-				conn, err = net.Dial("tcp", client.address)
-			} else {
-				conn, err = net.DialTimeout("tcp", client.address, client.dialTimeout)
+			if client.shutdownCtx.Err() != nil {
+				wrapper.drain(ErrShutdown{})
+				return
+			}
+
+			ctx := client.shutdownCtx
+			var cancel context.CancelFunc
+			if client.dialTimeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, client.dialTimeout)
+			}
+			if client.structuredLogger != nil {
+				client.structuredLogger.OnDial(client.address)
+			}
+			conn, err = client.dial(ctx)
+			if cancel != nil {
+				cancel()
 			}
 			if err != nil {
+				if client.shutdownCtx.Err() != nil {
+					wrapper.drain(ErrShutdown{})
+					return
+				}
 				if client.printer != nil {
 					client.printer.Print(ErrDialFailure{client.address, err})
 				}
-				time.Sleep(retry)
-				retry *= 2
-				if retry > client.retryMax {
-					retry = client.retryMax
+				if client.structuredLogger != nil {
+					client.structuredLogger.OnDialError(client.address, err)
+				}
+				wait := client.backOff.NextBackOff()
+				if wait == Stop {
+					wrapper.drain(ErrMaxElapsedTime{err})
+					return
+				}
+				attempt++
+				if client.structuredLogger != nil {
+					client.structuredLogger.OnReconnect(attempt, wait)
+				}
+				select {
+				case <-time.After(wait):
+				case <-client.shutdownCtx.Done():
+					wrapper.drain(ErrShutdown{})
+					return
 				}
 				continue
 			}
 
+			attempt = 0
+			atomic.StoreInt32(&client.connected, 1)
 			closed, err := wrapper.proxy(conn)
-			if err != nil && client.printer != nil {
-				client.printer.Print(err)
+			atomic.StoreInt32(&client.connected, 0)
+			if err != nil {
+				if client.printer != nil {
+					client.printer.Print(err)
+				}
+				if client.structuredLogger != nil {
+					client.structuredLogger.OnDisconnect(client.address, err)
+				}
 			}
 			if closed {
+				if client.shutdownCtx.Err() != nil {
+					wrapper.drain(ErrShutdown{})
+				} else {
+					wrapper.drain(ErrClosedConnection{})
+				}
+				return
+			}
+			if client.shutdownCtx.Err() != nil {
+				wrapper.drain(ErrShutdown{})
+				return
+			}
+			client.backOff.Reset()
+			wait := client.backOff.NextBackOff()
+			attempt++
+			if client.structuredLogger != nil {
+				client.structuredLogger.OnReconnect(attempt, wait)
+			}
+			select {
+			case <-time.After(wait):
+			case <-client.shutdownCtx.Done():
+				wrapper.drain(ErrShutdown{})
 				return
 			}
-			retry = client.retryMin
-			time.Sleep(retry)
 		}
 	}(client)
 	return client, nil
 }
 
-func (client *Conn) proxy(rwc io.ReadWriteCloser) (bool, error) {
+// drain permanently fails all pending and future I/O operations with err. It is invoked once the
+// reconnect goroutine decides it will never establish another connection, whether because the
+// configured BackOff gave up or because Shutdown was called.
+func (client *Conn) drain(err error) {
+	for job := range client.jobs {
+		job.results <- rillResult{err: err}
+	}
+}
+
+func (client *Conn) proxy(rwc net.Conn) (bool, error) {
+	br := bufio.NewReader(rwc)
 	var closed bool
 	for job := range client.jobs {
 		if closed {
@@ -187,15 +371,33 @@ func (client *Conn) proxy(rwc io.ReadWriteCloser) (bool, error) {
 		}
 		switch job.op {
 		case _read:
-			n, err := rwc.Read(job.data)
-			job.results <- rillResult{n, err}
+			rwc.SetReadDeadline(client.currentReadDeadline())
+			n, err := br.Read(job.data)
+			job.results <- rillResult{n: n, err: err}
 			if err != nil {
 				rwc.Close()
 				return false, err
 			}
 		case _write:
+			rwc.SetWriteDeadline(client.currentWriteDeadline())
 			n, err := rwc.Write(job.data)
-			job.results <- rillResult{n, err}
+			job.results <- rillResult{n: n, err: err}
+			if err != nil {
+				rwc.Close()
+				return false, err
+			}
+		case _readFrame:
+			rwc.SetReadDeadline(client.currentReadDeadline())
+			frame, err := client.framer.ReadFrame(br)
+			job.results <- rillResult{frame: frame, err: err}
+			if err != nil {
+				rwc.Close()
+				return false, err
+			}
+		case _writeFrame:
+			rwc.SetWriteDeadline(client.currentWriteDeadline())
+			n, err := client.framer.WriteFrame(rwc, job.data)
+			job.results <- rillResult{n: n, err: err}
 			if err != nil {
 				rwc.Close()
 				return false, err
@@ -210,39 +412,234 @@ func (client *Conn) proxy(rwc io.ReadWriteCloser) (bool, error) {
 	return false, nil
 }
 
+// currentReadDeadline returns the deadline that should be applied to the next Read: the rolling
+// ReadTimeout when one was configured, otherwise whatever absolute deadline was last set via
+// SetDeadline or SetReadDeadline (the zero Time if none).
+func (client *Conn) currentReadDeadline() time.Time {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.readTimeout > 0 {
+		return time.Now().Add(client.readTimeout)
+	}
+	return client.readDeadline
+}
+
+// currentWriteDeadline returns the deadline that should be applied to the next Write, following the
+// same precedence as currentReadDeadline.
+func (client *Conn) currentWriteDeadline() time.Time {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.writeTimeout > 0 {
+		return time.Now().Add(client.writeTimeout)
+	}
+	return client.writeDeadline
+}
+
+// SetDeadline sets the read and write deadlines applied to the underlying connection. The deadline
+// is stored on the Conn wrapper and reapplied before every I/O operation, including across
+// reconnects, since Read and Write serialize through a job queue that may swap out the underlying
+// net.Conn at any time. A configured ReadTimeout or WriteTimeout takes precedence over the
+// corresponding deadline set here.
+func (client *Conn) SetDeadline(t time.Time) error {
+	client.mu.Lock()
+	client.readDeadline = t
+	client.writeDeadline = t
+	client.mu.Unlock()
+	return nil
+}
+
+// SetReadDeadline sets the deadline applied before every Read, surviving reconnects the same way
+// SetDeadline does. It has no effect while a ReadTimeout is configured.
+func (client *Conn) SetReadDeadline(t time.Time) error {
+	client.mu.Lock()
+	client.readDeadline = t
+	client.mu.Unlock()
+	return nil
+}
+
+// SetWriteDeadline sets the deadline applied before every Write, surviving reconnects the same way
+// SetDeadline does. It has no effect while a WriteTimeout is configured.
+func (client *Conn) SetWriteDeadline(t time.Time) error {
+	client.mu.Lock()
+	client.writeDeadline = t
+	client.mu.Unlock()
+	return nil
+}
+
+// WriteMessage writes data as a single frame using the configured Framer, preserving the message
+// boundary across reconnects: a partially written frame closes the underlying connection, just
+// like a partial Write does, so a reconnect happens before the next message is emitted rather than
+// the peer seeing a spliced message. WriteMessage requires a Framer to have been configured via
+// WithFramer.
+func (client *Conn) WriteMessage(data []byte) (int, error) {
+	return client.WriteMessageContext(context.Background(), data)
+}
+
+// ReadMessage reads and returns the next complete frame using the configured Framer. ReadMessage
+// requires a Framer to have been configured via WithFramer.
+func (client *Conn) ReadMessage() ([]byte, error) {
+	return client.ReadMessageContext(context.Background())
+}
+
+// WriteMessageContext writes data as a single frame using the configured Framer, honoring ctx the
+// same way WriteContext does: a down connection can otherwise block WriteMessage forever waiting
+// for the proxy goroutine, which is itself stuck inside the dialer. When a Fallback has been
+// configured and the connection is currently down, the frame is written to the Fallback instead,
+// the same as WriteContext does for unframed writes.
+func (client *Conn) WriteMessageContext(ctx context.Context, data []byte) (int, error) {
+	if client.framer == nil {
+		return 0, ErrNoFramer{}
+	}
+	if client.fallback != nil && atomic.LoadInt32(&client.connected) == 0 {
+		return client.framer.WriteFrame(client.fallback, data)
+	}
+
+	job := newRillJob(_writeFrame, data)
+
+	select {
+	case client.jobs <- job:
+	case <-ctx.Done():
+		return 0, ErrIOError{_writeFrame, ctx.Err()}
+	}
+
+	select {
+	case result := <-job.results:
+		if result.err != nil {
+			result.err = ErrIOError{_writeFrame, result.err}
+		}
+		return result.n, result.err
+	case <-ctx.Done():
+		return 0, ErrIOError{_writeFrame, ctx.Err()}
+	}
+}
+
+// ReadMessageContext reads and returns the next complete frame using the configured Framer,
+// honoring ctx the same way ReadContext does.
+func (client *Conn) ReadMessageContext(ctx context.Context) ([]byte, error) {
+	if client.framer == nil {
+		return nil, ErrNoFramer{}
+	}
+	job := newRillJob(_readFrame, nil)
+
+	select {
+	case client.jobs <- job:
+	case <-ctx.Done():
+		return nil, ErrIOError{_readFrame, ctx.Err()}
+	}
+
+	select {
+	case result := <-job.results:
+		if result.err != nil {
+			result.err = ErrIOError{_readFrame, result.err}
+		}
+		return result.frame, result.err
+	case <-ctx.Done():
+		return nil, ErrIOError{_readFrame, ctx.Err()}
+	}
+}
+
 // Read reads data from the connection.
 func (client *Conn) Read(data []byte) (int, error) {
+	return client.ReadContext(context.Background(), data)
+}
+
+// Write writes data to the connection.
+func (client *Conn) Write(data []byte) (int, error) {
+	return client.WriteContext(context.Background(), data)
+}
+
+// Close closes the connection.
+func (client *Conn) Close() error {
+	return client.CloseContext(context.Background())
+}
+
+// ReadContext reads data from the connection, honoring ctx both while the job waits in the queue
+// and while it waits for the proxy goroutine to service it. Today the remote host being down can
+// otherwise block Read forever, because the proxy goroutine that would service the job is itself
+// stuck inside the dialer; ReadContext gives the caller a way out. ctx.Err() is wrapped in
+// ErrIOError so callers can treat it the same as any other I/O failure.
+func (client *Conn) ReadContext(ctx context.Context, data []byte) (int, error) {
 	job := newRillJob(_read, make([]byte, len(data)))
-	client.jobs <- job
 
-	result := <-job.results
-	copy(data, job.data)
-	if result.err != nil {
-		result.err = ErrIOError{_read, result.err}
+	select {
+	case client.jobs <- job:
+	case <-ctx.Done():
+		return 0, ErrIOError{_read, ctx.Err()}
+	}
+
+	select {
+	case result := <-job.results:
+		copy(data, job.data)
+		if result.err != nil {
+			result.err = ErrIOError{_read, result.err}
+		}
+		return result.n, result.err
+	case <-ctx.Done():
+		return 0, ErrIOError{_read, ctx.Err()}
 	}
-	return result.n, result.err
 }
 
-// Write writes data to the connection.
-func (client *Conn) Write(data []byte) (int, error) {
+// WriteContext writes data to the connection, honoring ctx the same way ReadContext does. When a
+// Fallback has been configured and the connection to the remote host is currently down,
+// WriteContext writes to the Fallback instead of waiting for the job queue to drain once the
+// connection comes back.
+func (client *Conn) WriteContext(ctx context.Context, data []byte) (int, error) {
+	if client.fallback != nil && atomic.LoadInt32(&client.connected) == 0 {
+		return client.fallback.Write(data)
+	}
+
 	job := newRillJob(_write, data)
-	client.jobs <- job
 
-	result := <-job.results
-	if result.err != nil {
-		result.err = ErrIOError{_write, result.err}
+	select {
+	case client.jobs <- job:
+	case <-ctx.Done():
+		return 0, ErrIOError{_write, ctx.Err()}
+	}
+
+	select {
+	case result := <-job.results:
+		if result.err != nil {
+			result.err = ErrIOError{_write, result.err}
+		}
+		return result.n, result.err
+	case <-ctx.Done():
+		return 0, ErrIOError{_write, ctx.Err()}
 	}
-	return result.n, result.err
 }
 
-// Close closes the connection.
-func (client *Conn) Close() error {
+// CloseContext closes the connection, honoring ctx the same way ReadContext does.
+func (client *Conn) CloseContext(ctx context.Context) error {
 	job := newRillJob(_close, nil)
-	client.jobs <- job
 
-	result := <-job.results
-	if result.err != nil {
-		result.err = ErrIOError{_close, result.err}
+	select {
+	case client.jobs <- job:
+	case <-ctx.Done():
+		return ErrIOError{_close, ctx.Err()}
+	}
+
+	select {
+	case result := <-job.results:
+		if result.err != nil {
+			result.err = ErrIOError{_close, result.err}
+		}
+		return result.err
+	case <-ctx.Done():
+		return ErrIOError{_close, ctx.Err()}
+	}
+}
+
+// Shutdown stops the reconnect goroutine from dialing again, after which it answers any jobs left
+// pending in the queue, and all later ones, with ErrShutdown; it also closes the underlying connection if one is currently established, without
+// racing the reconnect goroutine for ownership of it. Unlike Close, Shutdown honors ctx, so a
+// caller can bound how long it is willing to wait when the remote host is unreachable.
+func (client *Conn) Shutdown(ctx context.Context) error {
+	client.shutdownCancel()
+
+	err := client.CloseContext(ctx)
+	if ioErr, ok := err.(ErrIOError); ok {
+		if _, ok := ioErr.Err.(ErrShutdown); ok {
+			return nil
+		}
 	}
-	return result.err
+	return err
 }
@@ -0,0 +1,263 @@
+package goperconn
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultMaxIdle is the default maximum number of idle Conn values a Pool will retain for reuse.
+const DefaultMaxIdle = 2
+
+// PoolOption is a function that modifies a Pool structure during initialization.
+type PoolOption func(*Pool) error
+
+// Addresses specifies the remote hosts the Pool dials. When more than one address is given, Get
+// fails over to the next address whenever creating the Conn or the configured HealthCheck fails on
+// the previous one. Creating a Conn does not wait for its connection to be established, so an
+// unreachable address is skipped only when HealthCheck rejects its Conn.
+func Addresses(addresses ...string) PoolOption {
+	return func(p *Pool) error {
+		p.addresses = addresses
+		return nil
+	}
+}
+
+// ConnOptions specifies the Configurator values used to build each underlying Conn the Pool
+// creates, minus Address, which the Pool supplies itself from Addresses.
+func ConnOptions(setters ...Configurator) PoolOption {
+	return func(p *Pool) error {
+		p.connOptions = setters
+		return nil
+	}
+}
+
+// MinIdle controls the number of idle connections NewPool creates up front for reuse. The Pool
+// does not replenish them once Get has taken them.
+func MinIdle(n int) PoolOption {
+	return func(p *Pool) error {
+		p.minIdle = n
+		return nil
+	}
+}
+
+// MaxIdle controls the maximum number of idle connections the Pool retains; a Conn returned to the
+// Pool via Put once this many are already idle is closed instead of retained.
+func MaxIdle(n int) PoolOption {
+	return func(p *Pool) error {
+		p.maxIdle = n
+		return nil
+	}
+}
+
+// MaxLifetime bounds how long a Conn may be reused before the Pool closes it rather than handing
+// it out again. Zero, the default, means connections are reused indefinitely.
+func MaxLifetime(duration time.Duration) PoolOption {
+	return func(p *Pool) error {
+		p.maxLifetime = duration
+		return nil
+	}
+}
+
+// HealthCheck specifies a function invoked against a Conn on checkout, before Get returns it to
+// the caller. A non-nil return discards the Conn and, when multiple Addresses are configured,
+// causes Get to fail over and try the next address.
+func HealthCheck(check func(*Conn) error) PoolOption {
+	return func(p *Pool) error {
+		p.healthCheck = check
+		return nil
+	}
+}
+
+// pooledConn tracks the address a Conn was dialed with and when it was created, so the Pool can
+// enforce MaxLifetime.
+type pooledConn struct {
+	conn      *Conn
+	address   string
+	createdAt time.Time
+}
+
+// Pool manages a small number of persistent Conn values to one or more addresses, so callers get
+// Redis- or database-client-like checkout semantics on top of goperconn's single-connection
+// primitive, which otherwise caps throughput at one in-flight request per remote host.
+type Pool struct {
+	addresses   []string
+	connOptions []Configurator
+	minIdle     int
+	maxIdle     int
+	maxLifetime time.Duration
+	healthCheck func(*Conn) error
+
+	next int64 // index into addresses for round-robin dialing, advanced atomically
+
+	mu         sync.Mutex
+	idle       []*pooledConn
+	checkedOut map[*Conn]*pooledConn
+	closed     bool
+}
+
+// NewPool returns a Pool ready to hand out Conn values dialed to the configured Addresses.
+func NewPool(setters ...PoolOption) (*Pool, error) {
+	pool := &Pool{
+		maxIdle:    DefaultMaxIdle,
+		checkedOut: make(map[*Conn]*pooledConn),
+	}
+	for _, setter := range setters {
+		if err := setter(pool); err != nil {
+			return nil, err
+		}
+	}
+	if len(pool.addresses) == 0 {
+		return nil, fmt.Errorf("cannot create Pool without at least one address")
+	}
+	if pool.maxIdle < 0 {
+		return nil, fmt.Errorf("cannot create Pool with max idle: %d", pool.maxIdle)
+	}
+	if pool.minIdle < 0 {
+		return nil, fmt.Errorf("cannot create Pool with min idle: %d", pool.minIdle)
+	}
+	if pool.minIdle > pool.maxIdle {
+		return nil, fmt.Errorf("cannot create Pool with min idle (%d) greater than max idle (%d)", pool.minIdle, pool.maxIdle)
+	}
+
+	for i := 0; i < pool.minIdle; i++ {
+		pc, err := pool.dial()
+		if err != nil {
+			return nil, err
+		}
+		pool.idle = append(pool.idle, pc)
+	}
+
+	return pool, nil
+}
+
+// dial establishes a new Conn to the next address in round-robin order.
+func (pool *Pool) dial() (*pooledConn, error) {
+	n := atomic.AddInt64(&pool.next, 1) - 1
+	address := pool.addresses[n%int64(len(pool.addresses))]
+
+	setters := append([]Configurator{Address(address)}, pool.connOptions...)
+	conn, err := New(setters...)
+	if err != nil {
+		return nil, err
+	}
+	return &pooledConn{conn: conn, address: address, createdAt: time.Now()}, nil
+}
+
+// Get checks out a Conn from the Pool, preferring an idle connection that has not exceeded
+// MaxLifetime and passes HealthCheck, dialing a new one otherwise. When multiple Addresses are
+// configured, Get fails over to the next address if HealthCheck rejects a freshly dialed Conn.
+func (pool *Pool) Get() (*Conn, error) {
+	pool.mu.Lock()
+	if pool.closed {
+		pool.mu.Unlock()
+		return nil, ErrClosedConnection{}
+	}
+	for len(pool.idle) > 0 {
+		pc := pool.idle[len(pool.idle)-1]
+		pool.idle = pool.idle[:len(pool.idle)-1]
+		pool.mu.Unlock()
+
+		if pool.maxLifetime > 0 && time.Since(pc.createdAt) > pool.maxLifetime {
+			pc.conn.Close()
+			pool.mu.Lock()
+			continue
+		}
+		if pool.healthCheck != nil {
+			if err := pool.healthCheck(pc.conn); err != nil {
+				pc.conn.Close()
+				pool.mu.Lock()
+				continue
+			}
+		}
+		pool.mu.Lock()
+		pool.checkedOut[pc.conn] = pc
+		pool.mu.Unlock()
+		return pc.conn, nil
+	}
+	pool.mu.Unlock()
+
+	var lastErr error
+	for range pool.addresses {
+		pc, err := pool.dial()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if pool.healthCheck != nil {
+			if err := pool.healthCheck(pc.conn); err != nil {
+				pc.conn.Close()
+				lastErr = err
+				continue
+			}
+		}
+		pool.mu.Lock()
+		pool.checkedOut[pc.conn] = pc
+		pool.mu.Unlock()
+		return pc.conn, nil
+	}
+	return nil, lastErr
+}
+
+// Put returns a Conn previously obtained from Get back to the Pool for reuse. When the Pool
+// already holds MaxIdle idle connections, or the Pool has been closed, conn is closed instead of
+// retained. Put preserves the createdAt recorded when conn was originally dialed, so a connection
+// that is checked out and returned repeatedly still ages out per MaxLifetime.
+//
+// Put is a no-op for a conn the Pool does not currently have checked out, whether because conn was
+// never obtained from Get or because it has already been returned via an earlier Put: the Pool
+// only knows what to do with a conn while checkedOut still owns it, and closing it unconditionally
+// here would close the very same Conn a prior Put already moved into idle.
+func (pool *Pool) Put(conn *Conn) {
+	pool.mu.Lock()
+	pc, ok := pool.checkedOut[conn]
+	if !ok {
+		pool.mu.Unlock()
+		return
+	}
+	delete(pool.checkedOut, conn)
+
+	if pool.closed || len(pool.idle) >= pool.maxIdle {
+		pool.mu.Unlock()
+		conn.Close()
+		return
+	}
+	pool.idle = append(pool.idle, pc)
+	pool.mu.Unlock()
+}
+
+// Do checks out a Conn, invokes fn with it, and returns it to the Pool, closing it instead of
+// returning it to the Pool when fn returns a non-nil error, since the Conn's state after a failed
+// operation is not trustworthy enough to hand to the next caller.
+func (pool *Pool) Do(fn func(net.Conn) error) error {
+	conn, err := pool.Get()
+	if err != nil {
+		return err
+	}
+	if err := fn(conn); err != nil {
+		conn.Close()
+		return err
+	}
+	pool.Put(conn)
+	return nil
+}
+
+// Close closes every idle Conn held by the Pool and prevents further Get calls from succeeding.
+// Conn values already checked out via Get are unaffected; callers should Close them directly.
+func (pool *Pool) Close() error {
+	pool.mu.Lock()
+	pool.closed = true
+	idle := pool.idle
+	pool.idle = nil
+	pool.mu.Unlock()
+
+	var firstErr error
+	for _, pc := range idle {
+		if err := pc.conn.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
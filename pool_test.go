@@ -0,0 +1,165 @@
+package goperconn
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestPoolGetPutReusesIdleConn(t *testing.T) {
+	pool, err := NewPool(Addresses("a"), ConnOptions(pipeDialer(echoServer)))
+	if err != nil {
+		t.Fatalf("NewPool: %s", err)
+	}
+	defer pool.Close()
+
+	conn, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	pool.Put(conn)
+
+	again, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if again != conn {
+		t.Error("Get after Put returned a different Conn; want the idle one reused")
+	}
+}
+
+func TestPoolDoublePutIsNoop(t *testing.T) {
+	pool, err := NewPool(Addresses("a"), ConnOptions(pipeDialer(echoServer)))
+	if err != nil {
+		t.Fatalf("NewPool: %s", err)
+	}
+	defer pool.Close()
+
+	conn, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	pool.Put(conn)
+	pool.Put(conn) // second Put of the same Conn must be a no-op, not close the idle copy
+
+	again, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if again != conn {
+		t.Fatal("Get after double Put returned a different Conn; want the idle one intact")
+	}
+	if _, err := again.Write([]byte("ping")); err != nil {
+		t.Errorf("Write on Conn surviving a double Put: %s", err)
+	}
+}
+
+func TestPoolMaxIdleClosesExcessPut(t *testing.T) {
+	pool, err := NewPool(Addresses("a"), ConnOptions(pipeDialer(echoServer)), MaxIdle(1))
+	if err != nil {
+		t.Fatalf("NewPool: %s", err)
+	}
+	defer pool.Close()
+
+	first, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	second, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+
+	pool.Put(first)
+	pool.Put(second) // idle already holds MaxIdle (1), so this one is closed instead of retained
+
+	if _, err := second.Write([]byte("ping")); err == nil {
+		t.Error("Write on Conn returned past MaxIdle: want error, got nil")
+	}
+}
+
+func TestPoolMaxLifetimeRetiresIdleConn(t *testing.T) {
+	pool, err := NewPool(Addresses("a"), ConnOptions(pipeDialer(echoServer)), MaxLifetime(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewPool: %s", err)
+	}
+	defer pool.Close()
+
+	first, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	pool.Put(first)
+
+	time.Sleep(20 * time.Millisecond)
+
+	second, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if second == first {
+		t.Error("Get returned a Conn past MaxLifetime; want a freshly dialed one")
+	}
+}
+
+func TestPoolHealthCheckFailsOverToNextAddress(t *testing.T) {
+	pool, err := NewPool(
+		Addresses("bad", "good"),
+		ConnOptions(pipeDialer(echoServer)),
+		HealthCheck(func(c *Conn) error {
+			if c.address == "bad" {
+				return errors.New("bad address fails health check")
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewPool: %s", err)
+	}
+	defer pool.Close()
+
+	conn, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if conn.address != "good" {
+		t.Errorf("Get address = %q; want failover to %q", conn.address, "good")
+	}
+}
+
+func TestPoolDoClosesConnOnError(t *testing.T) {
+	pool, err := NewPool(Addresses("a"), ConnOptions(pipeDialer(echoServer)))
+	if err != nil {
+		t.Fatalf("NewPool: %s", err)
+	}
+	defer pool.Close()
+
+	wantErr := errors.New("boom")
+	if err := pool.Do(func(net.Conn) error { return wantErr }); err != wantErr {
+		t.Fatalf("Do = %v; want %v", err, wantErr)
+	}
+
+	pool.mu.Lock()
+	idle := len(pool.idle)
+	pool.mu.Unlock()
+	if idle != 0 {
+		t.Errorf("idle conns after failed Do = %d; want 0 (conn should be closed, not retained)", idle)
+	}
+}
+
+func TestPoolCloseRejectsFurtherGet(t *testing.T) {
+	pool, err := NewPool(Addresses("a"), ConnOptions(pipeDialer(echoServer)), MinIdle(1))
+	if err != nil {
+		t.Fatalf("NewPool: %s", err)
+	}
+
+	if err := pool.Close(); err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+
+	_, err = pool.Get()
+	if _, ok := err.(ErrClosedConnection); !ok {
+		t.Errorf("Get after Close = %v; want ErrClosedConnection", err)
+	}
+}
@@ -6,6 +6,8 @@ const (
 	_close opcode = iota
 	_read
 	_write
+	_readFrame
+	_writeFrame
 )
 
 // rillJob represents a job to perform either a read or write operation to a stream
@@ -21,8 +23,9 @@ func newRillJob(op opcode, data []byte) *rillJob {
 
 // rillResult represents the return values for a read or write operation to a stream
 type rillResult struct {
-	n   int
-	err error
+	n     int
+	frame []byte
+	err   error
 }
 
 // ErrClosedConnection is returned when I/O operation attempted on closed connection.
@@ -46,6 +49,33 @@ func (e ErrDialFailure) Error() string {
 	return "cannot connect: " + e.Err.Error()
 }
 
+// ErrShutdown is returned by pending and future I/O operations once Shutdown has been called,
+// stopping the reconnect goroutine from dialing again.
+type ErrShutdown struct{}
+
+func (e ErrShutdown) Error() string {
+	return "connection has been shut down"
+}
+
+// ErrNoFramer is returned by WriteMessage, ReadMessage, and their Context variants when the Conn
+// was not configured with a Framer via WithFramer.
+type ErrNoFramer struct{}
+
+func (e ErrNoFramer) Error() string {
+	return "cannot frame message: no Framer configured"
+}
+
+// ErrMaxElapsedTime is returned by pending and future I/O operations once the configured BackOff
+// has reported that MaxElapsedTime has elapsed, causing the library to give up reconnecting to the
+// remote host.
+type ErrMaxElapsedTime struct {
+	Err error
+}
+
+func (e ErrMaxElapsedTime) Error() string {
+	return "giving up reconnecting: " + e.Err.Error()
+}
+
 // ErrIOError is optionally sent to the configured warning hookback when an I/O operation fails. The
 // library will close and attempt to reestablish the connection, but this error is useful for client
 // application logging purposes.
@@ -62,6 +92,10 @@ func (e ErrIOError) Error() string {
 		return "cannot write: " + e.Err.Error()
 	case _close:
 		return "cannot close: " + e.Err.Error()
+	case _readFrame:
+		return "cannot read message: " + e.Err.Error()
+	case _writeFrame:
+		return "cannot write message: " + e.Err.Error()
 	default:
 		return "unknown error: " + e.Err.Error()
 	}